@@ -0,0 +1,59 @@
+package tensorflow
+
+// #include <stdlib.h>
+// #include "tensorflow/c/c_api.h"
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// setAttr sets the attribute named name on desc to value, dispatching on
+// value's Go type to the matching TF_SetAttr* call. The set of supported
+// types matches what OpSpec.Attrs and the op package's generated optional
+// attributes actually produce.
+func setAttr(desc *C.TF_OperationDescription, name string, value interface{}) error {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	switch v := value.(type) {
+	case string:
+		cstr := C.CString(v)
+		defer C.free(unsafe.Pointer(cstr))
+		C.TF_SetAttrString(desc, cname, unsafe.Pointer(cstr), C.size_t(len(v)))
+	case int64:
+		C.TF_SetAttrInt(desc, cname, C.int64_t(v))
+	case int:
+		C.TF_SetAttrInt(desc, cname, C.int64_t(v))
+	case float32:
+		C.TF_SetAttrFloat(desc, cname, C.float(v))
+	case bool:
+		var b C.uchar
+		if v {
+			b = 1
+		}
+		C.TF_SetAttrBool(desc, cname, b)
+	case DataType:
+		C.TF_SetAttrType(desc, cname, C.TF_DataType(v))
+	case Shape:
+		dims := make([]C.int64_t, v.NumDimensions())
+		for i := range dims {
+			dims[i] = C.int64_t(v.Size(i))
+		}
+		var dimsPtr *C.int64_t
+		if len(dims) > 0 {
+			dimsPtr = &dims[0]
+		}
+		C.TF_SetAttrShape(desc, cname, dimsPtr, C.int(len(dims)))
+	case *Tensor:
+		status := newStatus()
+		C.TF_SetAttrTensor(desc, cname, v.c, status.c)
+		if err := status.Err(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("attribute values of type %T are not supported", value)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Command gen-bazel walks the packages under tensorflow/contrib/go and
+// writes a BUILD.bazel file for each, replacing the hand-maintained BUILD
+// files. It does not replace the other go:generate steps in gen.go: the
+// mkdir/cp of ops.pbtxt and the godoc runs still execute as shell commands.
+//
+// For every package it declares a go_library (or go_binary, for package
+// main) target and, when _test.go files are present, a go_test target.
+// Packages that contain .go files with `import "C"` additionally get
+// cgo_library-style copts/cxxopts/cdeps populated from the cgo preprocessor
+// flags that `go list -json` reports for that package.
+//
+// Usage:
+//
+//	go run ./cmd/gen-bazel [-root .]
+//
+// It is invoked via `go generate ./...` (see gen.go) and its output is run
+// through buildifier before being written to disk, so that the generated
+// BUILD.bazel files match the formatting of hand-written ones elsewhere in
+// the repository.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func main() {
+	root := flag.String("root", ".", "directory to walk for Go packages, relative to the working directory")
+	flag.Parse()
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedCompiledGoFiles,
+		Dir:  *root,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		log.Fatalf("gen-bazel: loading packages: %v", err)
+	}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			log.Fatalf("gen-bazel: package %s: %v", pkg.PkgPath, pkg.Errors[0])
+		}
+		dir, err := packageDir(pkg)
+		if err != nil {
+			log.Fatalf("gen-bazel: %s: %v", pkg.PkgPath, err)
+		}
+		build, err := renderBuild(pkg)
+		if err != nil {
+			log.Fatalf("gen-bazel: rendering BUILD.bazel for %s: %v", pkg.PkgPath, err)
+		}
+		formatted, err := buildify(build)
+		if err != nil {
+			log.Fatalf("gen-bazel: buildifier failed for %s: %v", pkg.PkgPath, err)
+		}
+		path := filepath.Join(dir, "BUILD.bazel")
+		if err := os.WriteFile(path, formatted, 0644); err != nil {
+			log.Fatalf("gen-bazel: writing %s: %v", path, err)
+		}
+	}
+}
+
+// packageDir returns the directory a package's sources live in, derived
+// from its first listed Go file.
+func packageDir(pkg *packages.Package) (string, error) {
+	if len(pkg.GoFiles) == 0 {
+		return "", fmt.Errorf("package has no Go files")
+	}
+	return filepath.Dir(pkg.GoFiles[0]), nil
+}
+
+type target struct {
+	Kind    string // "go_library", "go_binary" or "go_test"
+	Name    string
+	Srcs    []string
+	Deps    []string
+	CgoSrcs []string
+	Copts   []string
+	Cxxopts []string
+	Cdeps   []string
+}
+
+var buildTmpl = template.Must(template.New("BUILD").Parse(`load("@io_bazel_rules_go//go:def.bzl", "go_library", "go_binary", "go_test")
+
+{{range .}}{{.Kind}}(
+    name = "{{.Name}}",
+    srcs = [{{range .Srcs}}
+        "{{.}}",{{end}}
+    ],
+{{- if .CgoSrcs}}
+    cgo = True,
+{{- end}}
+{{- if .Copts}}
+    copts = [{{range .Copts}}
+        "{{.}}",{{end}}
+    ],
+{{- end}}
+{{- if .Cxxopts}}
+    cxxopts = [{{range .Cxxopts}}
+        "{{.}}",{{end}}
+    ],
+{{- end}}
+{{- if .Cdeps}}
+    cdeps = [{{range .Cdeps}}
+        "{{.}}",{{end}}
+    ],
+{{- end}}
+    deps = [{{range .Deps}}
+        "{{.}}",{{end}}
+    ],
+)
+
+{{end}}`))
+
+// renderBuild builds the (unformatted) BUILD.bazel contents for pkg.
+func renderBuild(pkg *packages.Package) ([]byte, error) {
+	var targets []target
+
+	libDeps := bazelDeps(pkg.Imports)
+	lib := target{
+		Kind: "go_library",
+		Name: "go_default_library",
+		Deps: libDeps,
+	}
+	var testSrcs []string
+	for _, f := range pkg.GoFiles {
+		name := filepath.Base(f)
+		if strings.HasSuffix(name, "_test.go") {
+			testSrcs = append(testSrcs, name)
+			continue
+		}
+		lib.Srcs = append(lib.Srcs, name)
+	}
+	flags, err := cgoFlags(filepath.Dir(pkg.GoFiles[0]))
+	if err != nil {
+		return nil, fmt.Errorf("reading cgo flags for %s: %v", pkg.PkgPath, err)
+	}
+	// go/packages is loaded without Tests, so its GoFiles hold no
+	// _test.go files; take them from `go list` instead.
+	testSrcs = append(testSrcs, flags.TestGoFiles...)
+	testSrcs = append(testSrcs, flags.XTestGoFiles...)
+	lib.CgoSrcs = flags.CgoFiles
+	if len(lib.CgoSrcs) > 0 {
+		lib.Copts = flags.CgoCFLAGS
+		lib.Cxxopts = flags.CgoCXXFLAGS
+		lib.Cdeps = flags.CgoLDFLAGS
+	}
+	if pkg.Name == "main" {
+		lib.Kind = "go_binary"
+		lib.Name = filepath.Base(pkg.PkgPath)
+	}
+	targets = append(targets, lib)
+
+	if len(testSrcs) > 0 {
+		targets = append(targets, target{
+			Kind: "go_test",
+			Name: "go_default_test",
+			Srcs: testSrcs,
+			Deps: append([]string{":" + lib.Name}, libDeps...),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := buildTmpl.Execute(&buf, targets); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// cgoBuildFlags mirrors the subset of `go list -json` output that describes
+// a package's cgo and test source files and the flags cgo passes to the
+// C/C++ compiler and linker for it.
+// CgoLDFLAGS is emitted as the target's cdeps: this generator treats cdeps
+// the same way it treats copts/cxxopts, as raw flags carried through from
+// cgo rather than bazel cc_library labels, since the packages here link
+// against a prebuilt libtensorflow.so rather than a Bazel-built cc_library.
+type cgoBuildFlags struct {
+	CgoFiles     []string
+	CgoCFLAGS    []string
+	CgoCXXFLAGS  []string
+	CgoLDFLAGS   []string
+	TestGoFiles  []string
+	XTestGoFiles []string
+}
+
+// cgoFlags shells out to `go list -json` for the package in dir to recover
+// its CgoFiles, CgoCFLAGS/CgoCXXFLAGS/CgoLDFLAGS and test files, which
+// go/packages does not expose directly.
+func cgoFlags(dir string) (*cgoBuildFlags, error) {
+	out, err := exec.Command("go", "list", "-json", dir).Output()
+	if err != nil {
+		return nil, err
+	}
+	var flags cgoBuildFlags
+	if err := json.Unmarshal(out, &flags); err != nil {
+		return nil, err
+	}
+	return &flags, nil
+}
+
+// buildify formats raw BUILD.bazel source through buildifier so the
+// generated files match the style of hand-written ones in the repo.
+func buildify(src []byte) ([]byte, error) {
+	cmd := exec.Command("buildifier")
+	cmd.Stdin = bytes.NewReader(src)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// bazelDeps converts a package's Go import paths into sorted, de-duped
+// bazel label strings, dropping anything in the standard library.
+const modulePrefix = "github.com/tensorflow/tensorflow/"
+
+func bazelDeps(imports map[string]*packages.Package) []string {
+	var deps []string
+	for path := range imports {
+		if !strings.Contains(path, ".") {
+			continue // standard library package
+		}
+		deps = append(deps, "//"+strings.TrimPrefix(path, modulePrefix)+":go_default_library")
+	}
+	sort.Strings(deps)
+	return deps
+}
@@ -0,0 +1,79 @@
+// Command gen-cgo-flags resolves a local libtensorflow installation on
+// platforms that have no Bazel-produced //tensorflow:libtensorflow.so to
+// link against (currently just darwin/arm64), and writes
+// platform_darwin_arm64.go, the generated file that supplies the #cgo
+// CFLAGS/LDFLAGS those platforms build with.
+//
+// It is invoked via the go:generate directive in gen.go and is a no-op on
+// every other GOOS/GOARCH, which keep building against the path produced
+// by the existing `bazel build //tensorflow:libtensorflow.so` step. The
+// "tf-lib" Makefile target wraps the -check flag so that `make build`
+// skips the Bazel step entirely when a system library is already present.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/tensorflow/tensorflow/tensorflow/contrib/go/internal/libresolve"
+)
+
+const defaultVersion = "2.16.1"
+
+func main() {
+	var (
+		version  = flag.String("version", defaultVersion, "libtensorflow release to download if none is found locally")
+		cacheDir = flag.String("cache-dir", filepath.Join(os.Getenv("HOME"), ".cache", "libtensorflow"), "directory to download a prebuilt libtensorflow into")
+		check    = flag.Bool("check", false, "exit 0 if running on darwin/arm64 with a libtensorflow already installed, 1 otherwise, without writing or downloading anything")
+		output   = flag.String("output", "platform_darwin_arm64.go", "generated file to write, relative to tensorflow/contrib/go")
+	)
+	flag.Parse()
+
+	offPlatform := runtime.GOOS != "darwin" || runtime.GOARCH != "arm64"
+
+	if *check {
+		// Nothing to resolve off darwin/arm64: report failure so callers
+		// (the Makefile's tf-lib target, gen.go's go:generate line) fall
+		// back to building //tensorflow:libtensorflow.so with Bazel.
+		if offPlatform {
+			os.Exit(1)
+		}
+		if _, err := libresolve.Find(); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if offPlatform {
+		log.Printf("gen-cgo-flags: GOOS/GOARCH is %s/%s, not darwin/arm64; nothing to do", runtime.GOOS, runtime.GOARCH)
+		return
+	}
+
+	lib, err := libresolve.Find()
+	if err != nil {
+		log.Printf("no local libtensorflow found (%v); downloading %s", err, *version)
+		lib, err = libresolve.Download(*version, *cacheDir)
+		if err != nil {
+			log.Fatalf("gen-cgo-flags: %v", err)
+		}
+	}
+
+	src := fmt.Sprintf(`// Code generated by gen-cgo-flags. DO NOT EDIT.
+
+//go:build darwin && arm64
+
+package tensorflow
+
+// #cgo CFLAGS: -I%s
+// #cgo LDFLAGS: -L%s -ltensorflow
+import "C"
+`, lib.IncludeDir, lib.LibDir)
+
+	if err := os.WriteFile(*output, []byte(src), 0644); err != nil {
+		log.Fatalf("gen-cgo-flags: writing %s: %v", *output, err)
+	}
+}
@@ -0,0 +1,21 @@
+package tensorflow
+
+// #include "tensorflow/c/c_api.h"
+import "C"
+
+// DataType holds the type of a Tensor's elements, and mirrors the values
+// of the TF_DataType enum in the C API.
+type DataType C.TF_DataType
+
+// Types of elements a Tensor or Output can hold, matching TF_DataType.
+const (
+	Float  DataType = C.TF_FLOAT
+	Double DataType = C.TF_DOUBLE
+	Int32  DataType = C.TF_INT32
+	Uint8  DataType = C.TF_UINT8
+	Int16  DataType = C.TF_INT16
+	Int8   DataType = C.TF_INT8
+	String DataType = C.TF_STRING
+	Int64  DataType = C.TF_INT64
+	Bool   DataType = C.TF_BOOL
+)
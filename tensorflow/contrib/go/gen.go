@@ -1,8 +1,14 @@
-//go:generate bazel build //tensorflow:libtensorflow.so
+//go:generate sh -c "go run ./cmd/gen-cgo-flags -check || bazel build //tensorflow:libtensorflow.so"
+//go:generate go run ./cmd/gen-cgo-flags
 //go:generate mkdir -p /usr/local/tensorlow/
 //go:generate cp ../../core/ops/ops.pbtxt /usr/local/tensorlow/
+//go:generate go run ./genop -pbtxt=/usr/local/tensorlow/ops.pbtxt -output=op/generated_wrappers.go
+//go:generate go run ./cmd/gen-bazel -root=.
 //go:generate sh -c "godoc  -ex=true -templates=godoc_tmpl/ cmd/github.com/tensorflow/tensorflow/tensorflow/contrib/go Tensor > g3doc/tensor.md"
 //go:generate sh -c "godoc  -ex=true -templates=godoc_tmpl/ cmd/github.com/tensorflow/tensorflow/tensorflow/contrib/go Session > g3doc/session.md"
 //go:generate sh -c "godoc  -ex=true -templates=godoc_tmpl/ cmd/github.com/tensorflow/tensorflow/tensorflow/contrib/go Graph > g3doc/graph.md"
 
+// BUILD.bazel files under this directory are generated by cmd/gen-bazel
+// and should not be hand-edited; rerun `go generate ./...` after adding or
+// removing source files instead.
 package tensorflow
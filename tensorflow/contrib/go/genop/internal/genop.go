@@ -0,0 +1,375 @@
+// Package internal implements the parsing of ops.pbtxt and the generation
+// of Go source for the op package's wrapper functions.
+//
+// ops.pbtxt is the text-format serialization of an OpList proto (see
+// tensorflow/core/framework/op_def.proto). Rather than depending on the
+// full protobuf toolchain, this package parses just the subset of the
+// text format that the C++ op registry emits: nested `key { ... }` / `key:
+// value` blocks, of which only the `op`, `input_arg`, `output_arg` and
+// `attr` blocks (and their `name`, `summary`, `type`, `type_attr`,
+// `number_attr`, `type_list_attr` and `default_value` fields) are
+// interpreted.
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// OpDef describes a single registered op, as parsed out of ops.pbtxt.
+type OpDef struct {
+	Name       string
+	Summary    string
+	InputArgs  []ArgDef
+	OutputArgs []ArgDef
+	Attrs      []AttrDef
+}
+
+// ArgDef describes a single input or output of an op.
+type ArgDef struct {
+	Name string
+	// TypeAttr, if set, names the attr that determines this arg's dtype
+	// (e.g. "T"). Such attrs are inferred from the arg at op-construction
+	// time and are not exposed as a separate Go parameter.
+	TypeAttr string
+	// NumberAttr and TypeListAttr, if set, name the attr that determines
+	// how many tensors this (list-typed) arg carries.
+	NumberAttr   string
+	TypeListAttr string
+}
+
+// IsList reports whether the arg accepts/produces a variable-length list
+// of tensors rather than a single one.
+func (a ArgDef) IsList() bool {
+	return a.NumberAttr != "" || a.TypeListAttr != ""
+}
+
+// AttrDef describes a single attribute of an op, including whether it has
+// a default (and is thus optional in the generated wrapper).
+type AttrDef struct {
+	Name       string
+	Type       string
+	HasDefault bool
+}
+
+// ParseOpList reads and parses the OpList text-format proto at path.
+func ParseOpList(path string) ([]*OpDef, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseOpList(f)
+}
+
+// entry is one `key: scalar` or `key { ... }` line of a textproto block.
+type entry struct {
+	key    string
+	scalar string
+	block  *block
+}
+
+// block is the nested-field list of a textproto message.
+type block struct {
+	entries []entry
+}
+
+func (b *block) get(key string) (string, bool) {
+	for _, e := range b.entries {
+		if e.key == key && e.block == nil {
+			return e.scalar, true
+		}
+	}
+	return "", false
+}
+
+func (b *block) blocks(key string) []*block {
+	var out []*block
+	for _, e := range b.entries {
+		if e.key == key && e.block != nil {
+			out = append(out, e.block)
+		}
+	}
+	return out
+}
+
+// parseBlock reads entries until a closing "}" (for a nested block) or
+// EOF (for the implicit top-level message).
+func parseBlock(sc *bufio.Scanner) (*block, error) {
+	b := &block{}
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case line == "}":
+			return b, nil
+		case strings.HasSuffix(line, "{"):
+			key := strings.TrimSpace(strings.TrimSuffix(line, "{"))
+			key = strings.TrimSpace(strings.TrimSuffix(key, ":"))
+			child, err := parseBlock(sc)
+			if err != nil {
+				return nil, err
+			}
+			b.entries = append(b.entries, entry{key: key, block: child})
+		default:
+			i := strings.IndexByte(line, ':')
+			if i < 0 {
+				continue
+			}
+			key := strings.TrimSpace(line[:i])
+			b.entries = append(b.entries, entry{key: key, scalar: unquote(strings.TrimSpace(line[i+1:]))})
+		}
+	}
+	return b, nil
+}
+
+func parseOpList(r io.Reader) ([]*OpDef, error) {
+	root, err := parseBlock(bufio.NewScanner(r))
+	if err != nil {
+		return nil, err
+	}
+	var ops []*OpDef
+	for _, b := range root.blocks("op") {
+		ops = append(ops, parseOpDef(b))
+	}
+	return ops, nil
+}
+
+func parseOpDef(b *block) *OpDef {
+	op := &OpDef{}
+	op.Name, _ = b.get("name")
+	op.Summary, _ = b.get("summary")
+	for _, argBlock := range b.blocks("input_arg") {
+		op.InputArgs = append(op.InputArgs, parseArgDef(argBlock))
+	}
+	for _, argBlock := range b.blocks("output_arg") {
+		op.OutputArgs = append(op.OutputArgs, parseArgDef(argBlock))
+	}
+	for _, attrBlock := range b.blocks("attr") {
+		op.Attrs = append(op.Attrs, parseAttrDef(attrBlock))
+	}
+	return op
+}
+
+func parseArgDef(b *block) ArgDef {
+	a := ArgDef{}
+	a.Name, _ = b.get("name")
+	a.TypeAttr, _ = b.get("type_attr")
+	a.NumberAttr, _ = b.get("number_attr")
+	a.TypeListAttr, _ = b.get("type_list_attr")
+	return a
+}
+
+func parseAttrDef(b *block) AttrDef {
+	a := AttrDef{}
+	a.Name, _ = b.get("name")
+	a.Type, _ = b.get("type")
+	a.HasDefault = len(b.blocks("default_value")) > 0
+	return a
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, `"`)
+}
+
+// scalarAttrGoType maps an OpDef attr's declared type to the Go type its
+// generated wrapper parameter (required) or functional option (optional)
+// uses. Attrs whose type isn't listed here (e.g. "list(int)", "func") are
+// not yet supported; ops that need one fall back to a minimal stub.
+var scalarAttrGoType = map[string]string{
+	"type":   "tf.DataType",
+	"int":    "int64",
+	"float":  "float32",
+	"bool":   "bool",
+	"string": "string",
+	"shape":  "tf.Shape",
+	"tensor": "*tf.Tensor",
+}
+
+// camelCase converts a snake_case attr name (e.g. "transpose_a") into the
+// CamelCase suffix used in its generated option name (e.g. "TransposeA").
+func camelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// argView and attrView are the template-ready shapes of an ArgDef/AttrDef.
+type argView struct {
+	Name   string
+	IsList bool
+}
+
+type attrView struct {
+	Name   string // the op's attribute name, e.g. "transpose_a"
+	Camel  string // e.g. "TransposeA"
+	GoType string
+}
+
+// wrapperView is the template-ready representation of one op's generated
+// wrapper function.
+type wrapperView struct {
+	Name          string
+	Summary       string
+	Inputs        []argView
+	RequiredAttrs []attrView
+	OptionalAttrs []attrView
+	Outputs       []string
+	HasOptional   bool
+}
+
+// buildWrapperView computes the Go shape of op's wrapper. ok is false if
+// op uses a feature this generator doesn't yet support (a variable-length
+// output, or an attribute type outside scalarAttrGoType); such ops fall
+// back to a minimal stub so the generated file still compiles.
+func buildWrapperView(op *OpDef) (view wrapperView, ok bool) {
+	view = wrapperView{Name: op.Name, Summary: op.Summary}
+
+	inferred := map[string]bool{}
+	for _, a := range op.InputArgs {
+		markInferred(inferred, a)
+	}
+	for _, a := range op.OutputArgs {
+		markInferred(inferred, a)
+	}
+
+	for _, a := range op.InputArgs {
+		view.Inputs = append(view.Inputs, argView{Name: a.Name, IsList: a.IsList()})
+	}
+	for _, a := range op.OutputArgs {
+		if a.IsList() {
+			return view, false
+		}
+		view.Outputs = append(view.Outputs, a.Name)
+	}
+	for _, a := range op.Attrs {
+		if inferred[a.Name] {
+			continue
+		}
+		goType, known := scalarAttrGoType[a.Type]
+		if !known {
+			return view, false
+		}
+		av := attrView{Name: a.Name, Camel: camelCase(a.Name), GoType: goType}
+		if a.HasDefault {
+			view.OptionalAttrs = append(view.OptionalAttrs, av)
+		} else {
+			view.RequiredAttrs = append(view.RequiredAttrs, av)
+		}
+	}
+	view.HasOptional = len(view.OptionalAttrs) > 0
+	return view, true
+}
+
+func markInferred(inferred map[string]bool, a ArgDef) {
+	if a.TypeAttr != "" {
+		inferred[a.TypeAttr] = true
+	}
+	if a.NumberAttr != "" {
+		inferred[a.NumberAttr] = true
+	}
+	if a.TypeListAttr != "" {
+		inferred[a.TypeListAttr] = true
+	}
+}
+
+var wrapperTmpl = template.Must(template.New("wrapper").Parse(`
+{{if .HasOptional}}// {{.Name}}Attr is an optional argument to {{.Name}}.
+type {{.Name}}Attr func(optionalAttr)
+
+{{range .OptionalAttrs}}// {{$.Name}}{{.Camel}} sets the optional {{.Name}} attribute to value.
+func {{$.Name}}{{.Camel}}(value {{.GoType}}) {{$.Name}}Attr {
+	return func(m optionalAttr) { m["{{.Name}}"] = value }
+}
+
+{{end}}{{end}}// {{.Name}} {{.Summary}}
+func {{.Name}}(scope *Scope{{range .Inputs}}, {{.Name}} {{if .IsList}}[]tf.Output{{else}}tf.Output{{end}}{{end}}{{range .RequiredAttrs}}, {{.Name}} {{.GoType}}{{end}}{{if .HasOptional}}, optional ...{{.Name}}Attr{{end}}) ({{range $i, $o := .Outputs}}{{if $i}}, {{end}}{{$o}} tf.Output{{end}}) {
+	attrs := optionalAttr{}
+{{range .RequiredAttrs}}	attrs["{{.Name}}"] = {{.Name}}
+{{end}}{{if .HasOptional}}	for _, a := range optional {
+		a(attrs)
+	}
+{{end}}	var input []tf.Input
+{{range .Inputs}}{{if .IsList}}	input = append(input, outputList({{.Name}})...)
+{{else}}	input = append(input, {{.Name}})
+{{end}}{{end}}	op, err := scope.graph.AddOperation(tf.OpSpec{
+		Type:                "{{.Name}}",
+		Name:                scope.opName("{{.Name}}"),
+		Input:               input,
+		Attrs:               attrs,
+		Device:              scope.device,
+		ControlDependencies: scope.controlDependencies,
+	})
+	if err != nil {
+		scope.UpdateErr("{{.Name}}", err)
+		return
+	}
+{{range $i, $o := .Outputs}}	{{$o}} = op.Output({{$i}})
+{{end}}	return
+}
+`))
+
+// fallbackTmpl is used for ops buildWrapperView can't fully type: it adds
+// an op of that type to the graph with no inputs and no attributes, and
+// exposes only the raw *tf.Operation rather than typed inputs/outputs. For
+// an op that has required inputs or attributes, AddOperation fails and the
+// error is recorded on the Scope.
+var fallbackTmpl = template.Must(template.New("fallback").Parse(`
+// {{.Name}} {{.Summary}}
+//
+// This op has a feature (a list-typed output, or an attribute type) genop
+// does not yet model as typed Go, so it is exposed only as a raw
+// Operation; see op/wrappers.go for the hand-written ops with full typing.
+func {{.Name}}(scope *Scope) (op *tf.Operation) {
+	op, err := scope.graph.AddOperation(tf.OpSpec{
+		Type: "{{.Name}}",
+		Name: scope.opName("{{.Name}}"),
+	})
+	if err != nil {
+		scope.UpdateErr("{{.Name}}", err)
+	}
+	return op
+}
+`))
+
+// WriteWrappers emits the generated wrappers.go contents for ops to w.
+//
+// Op definitions that already have a hand-written, richer wrapper in
+// wrappers.go (such as Const, Placeholder, Add and MatMul) are skipped so
+// that re-running the generator does not clobber them.
+func WriteWrappers(w io.Writer, ops []*OpDef) error {
+	handWritten := map[string]bool{
+		"Const": true, "Placeholder": true, "Add": true, "MatMul": true,
+	}
+	fmt.Fprintln(w, "// Code generated by genop from ops.pbtxt. DO NOT EDIT.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "package op")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `import tf "github.com/tensorflow/tensorflow/tensorflow/contrib/go"`)
+	for _, op := range ops {
+		if handWritten[op.Name] {
+			continue
+		}
+		view, ok := buildWrapperView(op)
+		if !ok {
+			if err := fallbackTmpl.Execute(w, op); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := wrapperTmpl.Execute(w, view); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+const samplePbtxt = `
+op {
+  name: "MatMul"
+  summary: "Multiply the matrix \"a\" by the matrix \"b\"."
+}
+op {
+  name: "Placeholder"
+  summary: "A placeholder op."
+}
+op {
+  name: "Sub"
+  input_arg {
+    name: "x"
+    type_attr: "T"
+  }
+  input_arg {
+    name: "y"
+    type_attr: "T"
+  }
+  output_arg {
+    name: "z"
+    type_attr: "T"
+  }
+  attr {
+    name: "T"
+    type: "type"
+  }
+  summary: "Returns x - y element-wise."
+}
+op {
+  name: "Conv2D"
+  input_arg {
+    name: "input"
+    type_attr: "T"
+  }
+  input_arg {
+    name: "filter"
+    type_attr: "T"
+  }
+  output_arg {
+    name: "output"
+    type_attr: "T"
+  }
+  attr {
+    name: "T"
+    type: "type"
+  }
+  attr {
+    name: "strides"
+    type: "list(int)"
+  }
+  summary: "Computes a 2-D convolution."
+}
+op {
+  name: "Foo"
+  input_arg {
+    name: "x"
+    type_attr: "T"
+  }
+  output_arg {
+    name: "y"
+    type_attr: "T"
+  }
+  attr {
+    name: "T"
+    type: "type"
+  }
+  attr {
+    name: "scale"
+    type: "float"
+  }
+  attr {
+    name: "bias"
+    type: "float"
+    default_value {
+      f: 0
+    }
+  }
+  summary: "An illustrative op with both required and optional attrs."
+}
+`
+
+func TestParseOpList(t *testing.T) {
+	ops, err := parseOpList(strings.NewReader(samplePbtxt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 5 {
+		t.Fatalf("got %d ops, want 5", len(ops))
+	}
+	if got, want := ops[0].Name, "MatMul"; got != want {
+		t.Errorf("ops[0].Name = %q, want %q", got, want)
+	}
+	if got, want := ops[1].Name, "Placeholder"; got != want {
+		t.Errorf("ops[1].Name = %q, want %q", got, want)
+	}
+
+	sub := ops[2]
+	if len(sub.InputArgs) != 2 || len(sub.OutputArgs) != 1 {
+		t.Fatalf("Sub: got %d input args and %d output args, want 2 and 1", len(sub.InputArgs), len(sub.OutputArgs))
+	}
+	if len(sub.Attrs) != 1 || sub.Attrs[0].Name != "T" {
+		t.Fatalf("Sub: got attrs %+v, want a single T attr", sub.Attrs)
+	}
+}
+
+func TestWriteWrappersSkipsHandWritten(t *testing.T) {
+	ops, err := parseOpList(strings.NewReader(samplePbtxt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf strings.Builder
+	if err := WriteWrappers(&buf, ops); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "func MatMul(") {
+		t.Errorf("generated output should not redefine the hand-written MatMul wrapper:\n%s", buf.String())
+	}
+}
+
+// TestWriteWrappersGeneratesTypedSignature exercises the real
+// generated-wrapper path (as opposed to TestWriteWrappersSkipsHandWritten,
+// which checks that the sample's MatMul, a hand-written op, is skipped). Sub's "T"
+// attr is inferred from its inputs/output and must not become an explicit
+// parameter, while its two tensor inputs and one tensor output must.
+func TestWriteWrappersGeneratesTypedSignature(t *testing.T) {
+	ops, err := parseOpList(strings.NewReader(samplePbtxt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf strings.Builder
+	if err := WriteWrappers(&buf, ops); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	const wantSub = `func Sub(scope *Scope, x tf.Output, y tf.Output) (z tf.Output) {`
+	if !strings.Contains(out, wantSub) {
+		t.Errorf("generated output missing typed Sub signature %q; got:\n%s", wantSub, out)
+	}
+	if !strings.Contains(out, "z = op.Output(0)") {
+		t.Errorf("generated Sub body does not assign its named output; got:\n%s", out)
+	}
+}
+
+// TestWriteWrappersRequiredAndOptionalAttrs checks that a required
+// (no-default) attr becomes a positional parameter while an optional
+// (has-default) attr becomes a functional option, matching the
+// hand-written wrappers' convention (e.g. PlaceholderShape, MatMulTransposeA).
+func TestWriteWrappersRequiredAndOptionalAttrs(t *testing.T) {
+	ops, err := parseOpList(strings.NewReader(samplePbtxt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf strings.Builder
+	if err := WriteWrappers(&buf, ops); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	const wantSig = `func Foo(scope *Scope, x tf.Output, scale float32, optional ...FooAttr) (y tf.Output) {`
+	if !strings.Contains(out, wantSig) {
+		t.Errorf("generated output missing Foo signature %q; got:\n%s", wantSig, out)
+	}
+	if !strings.Contains(out, `type FooAttr func(optionalAttr)`) {
+		t.Errorf("generated output missing FooAttr option type; got:\n%s", out)
+	}
+	const wantOption = `func FooBias(value float32) FooAttr {`
+	if !strings.Contains(out, wantOption) {
+		t.Errorf("generated output missing FooBias option constructor %q; got:\n%s", wantOption, out)
+	}
+}
+
+// TestWriteWrappersFallsBackForUnsupportedAttrTypes checks that an op
+// using an attribute type genop doesn't model (e.g. Conv2D's
+// list(int)-typed "strides") falls back to the minimal, honestly
+// untyped stub instead of emitting an incorrect typed signature.
+func TestWriteWrappersFallsBackForUnsupportedAttrTypes(t *testing.T) {
+	ops, err := parseOpList(strings.NewReader(samplePbtxt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf strings.Builder
+	if err := WriteWrappers(&buf, ops); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	const wantFallback = `func Conv2D(scope *Scope) (op *tf.Operation) {`
+	if !strings.Contains(out, wantFallback) {
+		t.Errorf("generated output missing fallback Conv2D stub %q; got:\n%s", wantFallback, out)
+	}
+	if strings.Contains(out, "func Conv2D(scope *Scope, input tf.Output") {
+		t.Errorf("Conv2D should not get a typed signature genop can't actually back with its strides attr; got:\n%s", out)
+	}
+}
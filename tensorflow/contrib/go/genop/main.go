@@ -0,0 +1,38 @@
+// Command genop generates the Go wrapper functions in
+// tensorflow/contrib/go/op/generated_wrappers.go from the OpDef protos
+// listed in ops.pbtxt. The hand-written wrappers in op/wrappers.go are
+// not part of its output.
+//
+// It is invoked via the go:generate directive in
+// tensorflow/contrib/go/gen.go and is not intended to be run directly by
+// end users.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/tensorflow/tensorflow/tensorflow/contrib/go/genop/internal"
+)
+
+func main() {
+	var (
+		pbtxt  = flag.String("pbtxt", "/usr/local/tensorlow/ops.pbtxt", "path to the ops.pbtxt file produced by the go:generate step in gen.go")
+		output = flag.String("output", "op/generated_wrappers.go", "path of the generated Go source file, relative to tensorflow/contrib/go")
+	)
+	flag.Parse()
+
+	ops, err := internal.ParseOpList(*pbtxt)
+	if err != nil {
+		log.Fatalf("failed to parse %s: %v", *pbtxt, err)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		log.Fatalf("failed to create %s: %v", *output, err)
+	}
+	defer f.Close()
+	if err := internal.WriteWrappers(f, ops); err != nil {
+		log.Fatalf("failed to generate %s: %v", *output, err)
+	}
+}
@@ -0,0 +1,104 @@
+package tensorflow
+
+// #include <stdlib.h>
+// #include "tensorflow/c/c_api.h"
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// OpSpec holds the parameters necessary to add an Operation to a Graph,
+// the Go-level equivalent of building up a NodeDef.
+type OpSpec struct {
+	// Type is the registered name of the op (e.g. "Add", "MatMul").
+	Type string
+	// Name is the name the Operation is known by within its Graph. It
+	// defaults to Type if empty.
+	Name string
+	// Input holds this Operation's inputs, in order. Each may be an
+	// Output produced by a previously added Operation.
+	Input []Input
+	// Attrs holds the op's attribute values, keyed by attribute name.
+	Attrs map[string]interface{}
+	// Device, if non-empty, constrains the Operation to the named
+	// device.
+	Device string
+	// ControlDependencies lists Operations that must execute before this
+	// one, independent of any data dependency.
+	ControlDependencies []*Operation
+}
+
+// Graph represents a TensorFlow computation graph: a set of Operations
+// and the Outputs that connect them.
+type Graph struct {
+	c *C.TF_Graph
+}
+
+// NewGraph returns a new, empty Graph.
+func NewGraph() *Graph {
+	g := &Graph{c: C.TF_NewGraph()}
+	runtime.SetFinalizer(g, (*Graph).finalize)
+	return g
+}
+
+// newGraph is an unexported alias for NewGraph, used where the package's
+// own code constructs a Graph without wanting to stutter tf.NewGraph().
+func newGraph() *Graph {
+	return NewGraph()
+}
+
+func (g *Graph) finalize() {
+	C.TF_DeleteGraph(g.c)
+}
+
+// Operation returns the Operation named name in the Graph, or nil if none
+// exists by that name.
+func (g *Graph) Operation(name string) *Operation {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	c := C.TF_GraphOperationByName(g.c, cname)
+	if c == nil {
+		return nil
+	}
+	return &Operation{c: c, graph: g}
+}
+
+// AddOperation adds an Operation to the Graph as described by spec.
+func (g *Graph) AddOperation(spec OpSpec) (*Operation, error) {
+	name := spec.Name
+	if name == "" {
+		name = spec.Type
+	}
+	cType := C.CString(spec.Type)
+	defer C.free(unsafe.Pointer(cType))
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	desc := C.TF_NewOperation(g.c, cType, cName)
+
+	for _, in := range spec.Input {
+		C.TF_AddInput(desc, in.c())
+	}
+	for _, dep := range spec.ControlDependencies {
+		C.TF_AddControlInput(desc, dep.c)
+	}
+	if spec.Device != "" {
+		cDevice := C.CString(spec.Device)
+		defer C.free(unsafe.Pointer(cDevice))
+		C.TF_SetDevice(desc, cDevice)
+	}
+	for key, val := range spec.Attrs {
+		if err := setAttr(desc, key, val); err != nil {
+			return nil, fmt.Errorf("%s(%q): invalid attr %q: %v", spec.Type, name, key, err)
+		}
+	}
+
+	status := newStatus()
+	c := C.TF_FinishOperation(desc, status.c)
+	if err := status.Err(); err != nil {
+		return nil, err
+	}
+	return &Operation{c: c, graph: g}, nil
+}
@@ -0,0 +1,192 @@
+// Package libresolve locates (and, if necessary, fetches) a libtensorflow
+// installation so that the cgo bindings in tensorflow/contrib/go can be
+// built without requiring a local Bazel build of
+// //tensorflow:libtensorflow.so.
+//
+// This is primarily needed on platforms the Bazel build does not target
+// from this package's go:generate pipeline, such as darwin/arm64, where
+// the simplest path is to link against a prebuilt libtensorflow instead.
+package libresolve
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Library describes a discovered (or downloaded) libtensorflow
+// installation: the directory containing tensorflow/c/c_api.h and the
+// directory containing libtensorflow.{so,dylib}.
+type Library struct {
+	IncludeDir string
+	LibDir     string
+}
+
+// candidateRoots are searched, in order, for an existing libtensorflow
+// installation, unless TENSORFLOW_ROOT overrides the search entirely.
+var candidateRoots = []string{
+	"/opt/homebrew",
+	"/usr/local",
+}
+
+// Find locates an installed libtensorflow, preferring the directory named
+// by the TENSORFLOW_ROOT environment variable if set. It returns an error
+// if none of the candidate roots contain both the headers and the shared
+// library.
+func Find() (*Library, error) {
+	if root := os.Getenv("TENSORFLOW_ROOT"); root != "" {
+		lib, err := libraryAt(root)
+		if err != nil {
+			return nil, fmt.Errorf("libresolve: TENSORFLOW_ROOT=%s: %v", root, err)
+		}
+		return lib, nil
+	}
+	for _, root := range candidateRoots {
+		if lib, err := libraryAt(root); err == nil {
+			return lib, nil
+		}
+	}
+	return nil, fmt.Errorf("libresolve: no libtensorflow found under %v or $TENSORFLOW_ROOT", candidateRoots)
+}
+
+// libraryAt checks whether root/include/tensorflow/c/c_api.h and
+// root/lib/libtensorflow.{so,dylib} both exist.
+func libraryAt(root string) (*Library, error) {
+	include := filepath.Join(root, "include")
+	if _, err := os.Stat(filepath.Join(include, "tensorflow", "c", "c_api.h")); err != nil {
+		return nil, err
+	}
+	lib := filepath.Join(root, "lib")
+	for _, name := range []string{"libtensorflow.dylib", "libtensorflow.so"} {
+		if _, err := os.Stat(filepath.Join(lib, name)); err == nil {
+			return &Library{IncludeDir: include, LibDir: lib}, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has headers but no libtensorflow.{so,dylib}", root)
+}
+
+// archiveURL returns the upstream release URL for the prebuilt
+// libtensorflow tarball matching the current GOOS/GOARCH and version.
+func archiveURL(version string) (string, error) {
+	var platform string
+	switch runtime.GOOS + "/" + runtime.GOARCH {
+	case "darwin/arm64":
+		platform = "darwin-arm64"
+	case "darwin/amd64":
+		platform = "darwin-x86_64"
+	case "linux/amd64":
+		platform = "linux-x86_64"
+	default:
+		return "", fmt.Errorf("libresolve: no prebuilt libtensorflow for %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+	return fmt.Sprintf("https://storage.googleapis.com/tensorflow/libtensorflow/libtensorflow-cpu-%s-%s.tar.gz", platform, version), nil
+}
+
+// Download fetches and extracts the prebuilt libtensorflow tarball for the
+// given version into destRoot, returning the resulting Library.
+func Download(version, destRoot string) (*Library, error) {
+	url, err := archiveURL(version)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("libresolve: fetching %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("libresolve: fetching %s: %s", url, resp.Status)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("libresolve: decompressing %s: %v", url, err)
+	}
+	defer gz.Close()
+
+	if err := os.MkdirAll(destRoot, 0755); err != nil {
+		return nil, err
+	}
+	if err := extractTar(tar.NewReader(gz), destRoot); err != nil {
+		return nil, fmt.Errorf("libresolve: extracting %s: %v", url, err)
+	}
+	return libraryAt(destRoot)
+}
+
+// extractTar extracts every entry read from tr into destRoot, rejecting any
+// entry (or symlink target) that would land outside destRoot.
+func extractTar(tr *tar.Reader, destRoot string) error {
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, err := sanitizeExtractPath(destRoot, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			f.Close()
+		case tar.TypeSymlink:
+			// Real libtensorflow releases ship libtensorflow.so/.dylib as a
+			// symlink to a versioned file (e.g. libtensorflow.so ->
+			// libtensorflow.so.2), so these can't just be skipped.
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if filepath.IsAbs(hdr.Linkname) {
+				return fmt.Errorf("symlink %q: absolute target %q escapes destination %q", hdr.Name, hdr.Linkname, destRoot)
+			}
+			linkName := filepath.Join(filepath.Dir(hdr.Name), hdr.Linkname)
+			if _, err := sanitizeExtractPath(destRoot, linkName); err != nil {
+				return fmt.Errorf("symlink %q: %v", hdr.Name, err)
+			}
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sanitizeExtractPath joins destRoot and name the way Download extracts a
+// tar entry, and rejects the result if it would land outside destRoot
+// (e.g. via a ".." path segment; an absolute name is joined under
+// destRoot like any other rather than rejected), guarding
+// against a malicious or corrupt tarball overwriting arbitrary files on
+// the host (CVE-2007-4559-style tar-slip).
+func sanitizeExtractPath(destRoot, name string) (string, error) {
+	target := filepath.Join(destRoot, name)
+	destRootWithSep := filepath.Clean(destRoot) + string(filepath.Separator)
+	if target != filepath.Clean(destRoot) && !strings.HasPrefix(target, destRootWithSep) {
+		return "", fmt.Errorf("tar entry %q escapes destination %q", name, destRoot)
+	}
+	return target, nil
+}
@@ -0,0 +1,129 @@
+package libresolve
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLibraryAt(t *testing.T) {
+	root := t.TempDir()
+	if _, err := libraryAt(root); err == nil {
+		t.Fatal("libraryAt(empty root): got nil error, want one")
+	}
+
+	include := filepath.Join(root, "include", "tensorflow", "c")
+	if err := os.MkdirAll(include, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(include, "c_api.h"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := libraryAt(root); err == nil {
+		t.Fatal("libraryAt(headers only): got nil error, want one")
+	}
+
+	libDir := filepath.Join(root, "lib")
+	if err := os.MkdirAll(libDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(libDir, "libtensorflow.dylib"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	lib, err := libraryAt(root)
+	if err != nil {
+		t.Fatalf("libraryAt(complete root): %v", err)
+	}
+	if lib.IncludeDir != filepath.Join(root, "include") || lib.LibDir != libDir {
+		t.Errorf("libraryAt(root) = %+v, want IncludeDir=%s LibDir=%s", lib, filepath.Join(root, "include"), libDir)
+	}
+}
+
+func TestSanitizeExtractPathRejectsTraversal(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{
+		"../../etc/passwd",
+		"lib/../../outside",
+	} {
+		if _, err := sanitizeExtractPath(root, name); err == nil {
+			t.Errorf("sanitizeExtractPath(%q, %q): got nil error, want one", root, name)
+		}
+	}
+
+	got, err := sanitizeExtractPath(root, filepath.Join("lib", "libtensorflow.so"))
+	if err != nil {
+		t.Fatalf("sanitizeExtractPath(well-behaved entry): %v", err)
+	}
+	if want := filepath.Join(root, "lib", "libtensorflow.so"); got != want {
+		t.Errorf("sanitizeExtractPath = %q, want %q", got, want)
+	}
+}
+
+func TestExtractTarSymlink(t *testing.T) {
+	root := t.TempDir()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	writeEntry := func(hdr *tar.Header, body []byte) {
+		hdr.Size = int64(len(body))
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("WriteHeader(%q): %v", hdr.Name, err)
+		}
+		if _, err := tw.Write(body); err != nil {
+			t.Fatalf("Write(%q): %v", hdr.Name, err)
+		}
+	}
+	writeEntry(&tar.Header{Name: "lib/", Typeflag: tar.TypeDir, Mode: 0755}, nil)
+	writeEntry(&tar.Header{Name: "lib/libtensorflow.so.2", Typeflag: tar.TypeReg, Mode: 0644}, []byte("fake shared library"))
+	writeEntry(&tar.Header{Name: "lib/libtensorflow.so", Typeflag: tar.TypeSymlink, Linkname: "libtensorflow.so.2"}, nil)
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar.Writer.Close: %v", err)
+	}
+
+	if err := extractTar(tar.NewReader(&buf), root); err != nil {
+		t.Fatalf("extractTar: %v", err)
+	}
+
+	link := filepath.Join(root, "lib", "libtensorflow.so")
+	target, err := os.Readlink(link)
+	if err != nil {
+		t.Fatalf("Readlink(%q): %v", link, err)
+	}
+	if target != "libtensorflow.so.2" {
+		t.Errorf("Readlink(%q) = %q, want %q", link, target, "libtensorflow.so.2")
+	}
+	if data, err := os.ReadFile(link); err != nil || string(data) != "fake shared library" {
+		t.Errorf("ReadFile(%q) = %q, %v, want %q, nil", link, data, err, "fake shared library")
+	}
+}
+
+func TestExtractTarRejectsSymlinkEscape(t *testing.T) {
+	root := t.TempDir()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	hdr := &tar.Header{Name: "lib/libtensorflow.so", Typeflag: tar.TypeSymlink, Linkname: "../../../etc/passwd"}
+	if err := tw.WriteHeader(hdr); err != nil {
+		t.Fatalf("WriteHeader: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar.Writer.Close: %v", err)
+	}
+
+	if err := extractTar(tar.NewReader(&buf), root); err == nil {
+		t.Fatal("extractTar(escaping symlink): got nil error, want one")
+	}
+}
+
+func TestArchiveURLCurrentPlatform(t *testing.T) {
+	// archiveURL is keyed off runtime.GOOS/GOARCH, so this test only
+	// verifies the happy path compiles and returns a non-empty URL for
+	// whatever platform the test runs on when it is one we support.
+	if url, err := archiveURL("2.0.0"); err != nil {
+		t.Logf("archiveURL: %v (expected on unsupported test platforms)", err)
+	} else if url == "" {
+		t.Error("archiveURL: got an empty URL and nil error")
+	}
+}
@@ -0,0 +1,151 @@
+// Package op defines functions for building Graphs of Operations using a
+// Scope, mirroring the ergonomics of the upstream tensorflow/go/op package.
+//
+// A Scope tracks the current name space, device assignment and control
+// dependencies, and accumulates any error encountered while the graph is
+// being built. Client code can thus chain a sequence of op.* calls and
+// check for errors once, at the end, via Scope.Finalize:
+//
+//	s := op.NewScope()
+//	c := op.Const(s.SubScope("greeting"), "hello")
+//	graph, err := s.Finalize()
+package op
+
+import (
+	"fmt"
+
+	tf "github.com/tensorflow/tensorflow/tensorflow/contrib/go"
+)
+
+// Scope encapsulates the state shared by a graph of Operations being
+// constructed, such as a common name prefix, device assignment, control
+// dependencies and an error if construction of any operation failed.
+//
+// Scopes are not safe for concurrent use by multiple goroutines.
+type Scope struct {
+	graph               *tf.Graph
+	namemap             map[string]int
+	namespace           string
+	controlDependencies []*tf.Operation
+	device              string
+	err                 *scopeErr
+}
+
+// scopeErr is shared by a Scope and all of its descendants so that an
+// error encountered anywhere in the tree is visible to the root.
+type scopeErr struct {
+	err error
+}
+
+// NewScope creates a Scope initialized with an empty Graph.
+func NewScope() *Scope {
+	return &Scope{
+		graph:   tf.NewGraph(),
+		namemap: make(map[string]int),
+		err:     new(scopeErr),
+	}
+}
+
+// SubScope returns a new Scope which will cause all operations added to the
+// graph to be namespaced with 'namespace'. If namespace collides with an
+// existing namespace within the parent scope, a suffix is appended to
+// guarantee that the new sub-scope is unique.
+func (s *Scope) SubScope(namespace string) *Scope {
+	namespace = s.uniqueName(namespace)
+	if s.namespace != "" {
+		namespace = s.namespace + "/" + namespace
+	}
+	return &Scope{
+		graph:               s.graph,
+		namemap:             make(map[string]int),
+		namespace:           namespace,
+		controlDependencies: s.controlDependencies,
+		device:              s.device,
+		err:                 s.err,
+	}
+}
+
+// WithDevice returns a new Scope which will cause all operations added to
+// the graph to be assigned to the device specified by the given device
+// placement string. A placement of "" clears the device assignment so that
+// the placer is free to choose.
+func (s *Scope) WithDevice(device string) *Scope {
+	child := s.child()
+	child.device = device
+	return child
+}
+
+// WithControlDependencies returns a new Scope which will cause all
+// operations added to the graph to execute only after every Operation in
+// deps has executed.
+func (s *Scope) WithControlDependencies(deps ...*tf.Operation) *Scope {
+	child := s.child()
+	child.controlDependencies = append(append([]*tf.Operation{}, s.controlDependencies...), deps...)
+	return child
+}
+
+// child returns a new Scope sharing this Scope's graph, name map,
+// namespace, device and error tracking, but otherwise independent of it.
+func (s *Scope) child() *Scope {
+	return &Scope{
+		graph:               s.graph,
+		namemap:             s.namemap,
+		namespace:           s.namespace,
+		controlDependencies: s.controlDependencies,
+		device:              s.device,
+		err:                 s.err,
+	}
+}
+
+// Err returns the error, if any, encountered while constructing the graph
+// rooted at this Scope.
+func (s *Scope) Err() error {
+	return s.err.err
+}
+
+// Finalize returns the Graph built by this Scope and all of its
+// descendants, or an error if any operation failed to build.
+func (s *Scope) Finalize() (*tf.Graph, error) {
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return s.graph, nil
+}
+
+// UpdateErr records that an operation named op failed to build with the
+// given error. Only the first error recorded for a Scope tree is retained.
+func (s *Scope) UpdateErr(op string, err error) {
+	if s.err.err == nil {
+		s.err.err = fmt.Errorf("failed to add operation %q: %v", op, err)
+	}
+}
+
+// opName returns a name for an operation of the given type, namespaced
+// under this Scope and disambiguated from any previously generated name.
+func (s *Scope) opName(typ string) string {
+	name := s.uniqueName(typ)
+	if s.namespace != "" {
+		name = s.namespace + "/" + name
+	}
+	return name
+}
+
+func (s *Scope) uniqueName(name string) string {
+	count := s.namemap[name]
+	s.namemap[name]++
+	if count == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s_%d", name, count)
+}
+
+// outputList converts a slice of Outputs into a slice of Inputs, for ops
+// whose generated wrapper accepts a variable-length list of tensors as a
+// single argument.
+func outputList(o []tf.Output) []tf.Input {
+	list := make([]tf.Input, len(o))
+	for i, v := range o {
+		list[i] = v
+	}
+	return list
+}
@@ -0,0 +1,36 @@
+package op
+
+import "testing"
+
+func TestOpNameNamespacing(t *testing.T) {
+	root := NewScope()
+	if got, want := root.opName("Const"), "Const"; got != want {
+		t.Errorf("root.opName(%q) = %q, want %q", "Const", got, want)
+	}
+
+	sub := root.SubScope("greeting")
+	if got, want := sub.opName("Const"), "greeting/Const"; got != want {
+		t.Errorf("sub.opName(%q) = %q, want %q", "Const", got, want)
+	}
+
+	nested := sub.SubScope("inner")
+	if got, want := nested.opName("Const"), "greeting/inner/Const"; got != want {
+		t.Errorf("nested.opName(%q) = %q, want %q", "Const", got, want)
+	}
+}
+
+func TestSubScopesDoNotCollide(t *testing.T) {
+	root := NewScope()
+	a := Const(root.SubScope("a"), int64(1))
+	b := Const(root.SubScope("b"), int64(2))
+	if err := root.Err(); err != nil {
+		t.Fatalf("building Consts under sibling subscopes: %v", err)
+	}
+	if a.Op.Name() == b.Op.Name() {
+		t.Errorf("Consts under distinct subscopes got the same op name %q", a.Op.Name())
+	}
+
+	if _, err := root.Finalize(); err != nil {
+		t.Fatalf("Finalize: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+// Hand-written wrappers for the ops that genop skips (Const, Placeholder,
+// Add and MatMul). genop writes its own output to generated_wrappers.go.
+
+package op
+
+import tf "github.com/tensorflow/tensorflow/tensorflow/contrib/go"
+
+// PlaceholderAttr is an optional argument to Placeholder.
+type PlaceholderAttr func(m optionalAttr)
+
+// PlaceholderShape sets the shape of the tensor produced by the op. The
+// default value ([], an unknown shape) is used if this attribute is not
+// set.
+func PlaceholderShape(value tf.Shape) PlaceholderAttr {
+	return func(m optionalAttr) { m["shape"] = value }
+}
+
+// Placeholder is a placeholder op for a value that will be fed into the
+// computation, with the given dtype.
+func Placeholder(scope *Scope, dtype tf.DataType, optional ...PlaceholderAttr) (output tf.Output) {
+	attrs := optionalAttr{"dtype": dtype}
+	for _, a := range optional {
+		a(attrs)
+	}
+	op, err := scope.graph.AddOperation(tf.OpSpec{
+		Type:                "Placeholder",
+		Name:                scope.opName("Placeholder"),
+		Attrs:               attrs,
+		Device:              scope.device,
+		ControlDependencies: scope.controlDependencies,
+	})
+	if err != nil {
+		scope.UpdateErr("Placeholder", err)
+		return
+	}
+	return op.Output(0)
+}
+
+// Const creates a constant Tensor holding value.
+func Const(scope *Scope, value interface{}) (output tf.Output) {
+	t, err := tf.NewTensor(value)
+	if err != nil {
+		scope.UpdateErr("Const", err)
+		return
+	}
+	op, err := scope.graph.AddOperation(tf.OpSpec{
+		Type: "Const",
+		Name: scope.opName("Const"),
+		Attrs: optionalAttr{
+			"dtype": t.DataType(),
+			"value": t,
+		},
+		Device:              scope.device,
+		ControlDependencies: scope.controlDependencies,
+	})
+	if err != nil {
+		scope.UpdateErr("Const", err)
+		return
+	}
+	return op.Output(0)
+}
+
+// Add returns x + y element-wise.
+//
+// Both x and y must be of the same type.
+func Add(scope *Scope, x tf.Output, y tf.Output) (z tf.Output) {
+	op, err := scope.graph.AddOperation(tf.OpSpec{
+		Type:                "Add",
+		Name:                scope.opName("Add"),
+		Input:               []tf.Input{x, y},
+		Device:              scope.device,
+		ControlDependencies: scope.controlDependencies,
+	})
+	if err != nil {
+		scope.UpdateErr("Add", err)
+		return
+	}
+	return op.Output(0)
+}
+
+// MatMulAttr is an optional argument to MatMul.
+type MatMulAttr func(m optionalAttr)
+
+// MatMulTransposeA sets whether a is transposed before multiplication. The
+// default value is false.
+func MatMulTransposeA(value bool) MatMulAttr {
+	return func(m optionalAttr) { m["transpose_a"] = value }
+}
+
+// MatMulTransposeB sets whether b is transposed before multiplication. The
+// default value is false.
+func MatMulTransposeB(value bool) MatMulAttr {
+	return func(m optionalAttr) { m["transpose_b"] = value }
+}
+
+// MatMul multiplies matrix a by matrix b, producing a * b.
+//
+// The inputs must, following any transpositions, be two-dimensional matrices
+// whose inner dimensions match (the number of columns of a must equal the
+// number of rows of b).
+func MatMul(scope *Scope, a tf.Output, b tf.Output, optional ...MatMulAttr) (product tf.Output) {
+	attrs := optionalAttr{}
+	for _, o := range optional {
+		o(attrs)
+	}
+	op, err := scope.graph.AddOperation(tf.OpSpec{
+		Type:                "MatMul",
+		Name:                scope.opName("MatMul"),
+		Input:               []tf.Input{a, b},
+		Attrs:               attrs,
+		Device:              scope.device,
+		ControlDependencies: scope.controlDependencies,
+	})
+	if err != nil {
+		scope.UpdateErr("MatMul", err)
+		return
+	}
+	return op.Output(0)
+}
+
+// optionalAttr holds the attribute map shared by an op's optional-attribute
+// functional options.
+type optionalAttr map[string]interface{}
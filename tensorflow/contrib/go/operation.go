@@ -0,0 +1,48 @@
+package tensorflow
+
+// #include "tensorflow/c/c_api.h"
+import "C"
+
+// Operation is a single computation node in a Graph; the unit of
+// execution and the producer of zero or more Outputs.
+type Operation struct {
+	c     *C.TF_Operation
+	graph *Graph
+}
+
+// Name returns the Operation's name within its Graph.
+func (op *Operation) Name() string {
+	return C.GoString(C.TF_OperationName(op.c))
+}
+
+// NumOutputs returns the number of tensors produced by op.
+func (op *Operation) NumOutputs() int {
+	return int(C.TF_OperationNumOutputs(op.c))
+}
+
+// Output returns the i'th output of op.
+func (op *Operation) Output(i int) Output {
+	return Output{Op: op, Index: i}
+}
+
+// Output represents one of the tensors produced by an Operation, and is
+// the unit that Graph edges (Operation inputs) are expressed in terms of.
+type Output struct {
+	Op    *Operation
+	Index int
+}
+
+// DataType returns the type of the tensor this Output produces.
+func (o Output) DataType() DataType {
+	return DataType(C.TF_OperationOutputType(o.c()))
+}
+
+func (o Output) c() C.TF_Output {
+	return C.TF_Output{oper: o.Op.c, index: C.int(o.Index)}
+}
+
+// Input is anything that can be fed as an input to an Operation being
+// built with OpSpec, such as an Output produced by another Operation.
+type Input interface {
+	c() C.TF_Output
+}
@@ -0,0 +1,80 @@
+package tensorflow
+
+// #include "tensorflow/c/c_api.h"
+import "C"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// minTFVersion is the oldest libtensorflow release this package's cgo
+// bindings are known to be ABI-compatible with. It is intentionally a
+// loose, major.minor floor rather than an exact match: libtensorflow's C
+// API is stable across patch releases.
+const minTFVersion = "2.0.0"
+
+// RuntimeVersion returns the version string of the libtensorflow runtime
+// actually loaded (helpful when platform_darwin_arm64.go or the
+// Bazel-built .so resolve to an unexpected install).
+func RuntimeVersion() string {
+	return C.GoString(C.TF_Version())
+}
+
+// runtimeVersionErr is populated once, at package init, so that it is
+// ready before the first Graph or Tensor is ever built, not just before
+// the first Session is created. init still fails fast in spirit, but by
+// recording the error for the caller to receive from NewSession or
+// LoadSavedModel rather than panicking or printing to stderr.
+var runtimeVersionErr = checkRuntimeVersion()
+
+// checkRuntimeVersion reports an error, rather than crashing the
+// importing binary, if the loaded libtensorflow is older than
+// minTFVersion and thus not ABI-compatible with these bindings.
+func checkRuntimeVersion() error {
+	version := RuntimeVersion()
+	ok, err := versionAtLeast(version, minTFVersion)
+	if err != nil {
+		return fmt.Errorf("tensorflow: parsing loaded libtensorflow version %q: %v", version, err)
+	}
+	if !ok {
+		return fmt.Errorf("tensorflow: loaded libtensorflow %s, but these bindings require >= %s; set TENSORFLOW_ROOT or rerun `go generate` to pick up a newer libtensorflow", version, minTFVersion)
+	}
+	return nil
+}
+
+// versionAtLeast reports whether version is >= min, comparing the
+// major.minor prefix of each as numbers rather than lexically (so, e.g.,
+// "2.9.0" correctly compares as less than "2.10.0").
+func versionAtLeast(version, min string) (bool, error) {
+	vMajor, vMinor, err := majorMinor(version)
+	if err != nil {
+		return false, err
+	}
+	minMajor, minMinor, err := majorMinor(min)
+	if err != nil {
+		return false, err
+	}
+	if vMajor != minMajor {
+		return vMajor > minMajor, nil
+	}
+	return vMinor >= minMinor, nil
+}
+
+// majorMinor parses the leading "major.minor" components of a
+// dot-separated version string such as "2.10.0" or "2.10.0-rc1".
+func majorMinor(version string) (major, minor int, err error) {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("expected a major.minor[.patch] version, got %q", version)
+	}
+	if major, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid major version in %q: %v", version, err)
+	}
+	minorPart := strings.SplitN(parts[1], "-", 2)[0]
+	if minor, err = strconv.Atoi(minorPart); err != nil {
+		return 0, 0, fmt.Errorf("invalid minor version in %q: %v", version, err)
+	}
+	return major, minor, nil
+}
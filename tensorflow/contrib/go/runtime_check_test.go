@@ -0,0 +1,35 @@
+package tensorflow
+
+import "testing"
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version, min string
+		want         bool
+	}{
+		{version: "2.0.0", min: "2.0.0", want: true},
+		{version: "2.10.0", min: "2.9.0", want: true},
+		{version: "2.9.0", min: "2.10.0", want: false}, // would fail under a lexical comparison
+		{version: "3.0.0", min: "2.10.0", want: true},
+		{version: "1.15.0", min: "2.0.0", want: false},
+		{version: "2.10.0-rc1", min: "2.9.0", want: true},
+	}
+	for _, test := range tests {
+		got, err := versionAtLeast(test.version, test.min)
+		if err != nil {
+			t.Errorf("versionAtLeast(%q, %q): unexpected error: %v", test.version, test.min, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("versionAtLeast(%q, %q) = %v, want %v", test.version, test.min, got, test.want)
+		}
+	}
+}
+
+func TestMajorMinorInvalid(t *testing.T) {
+	for _, version := range []string{"", "2", "a.b.c"} {
+		if _, _, err := majorMinor(version); err == nil {
+			t.Errorf("majorMinor(%q): got nil error, want one", version)
+		}
+	}
+}
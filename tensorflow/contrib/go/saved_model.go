@@ -0,0 +1,176 @@
+package tensorflow
+
+// #include <stdlib.h>
+// #include "tensorflow/c/c_api.h"
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	corepb "github.com/tensorflow/tensorflow/tensorflow/core/protobuf"
+)
+
+// SavedModel represents the contents of loaded SavedModel.
+//
+// It bundles the Graph and Session constructed from the on-disk
+// representation, along with the SignatureDefs declared in the model's
+// MetaGraphDef, so that a model exported from Python can be run without
+// the caller having to know the underlying op or tensor names.
+type SavedModel struct {
+	Graph      *Graph
+	Session    *Session
+	Signatures map[string]*corepb.SignatureDef
+}
+
+// LoadSavedModel loads the SavedModel found in exportDir, restricted to the
+// MetaGraphDef tagged with all of tags, returning its Graph, a Session
+// ready to run it, and its SignatureDefs.
+//
+// exportDir is expected to contain a saved_model.pb (or
+// saved_model.pbtxt) as produced by tf.saved_model.builder in Python.
+func LoadSavedModel(exportDir string, tags []string, options *SessionOptions) (*SavedModel, error) {
+	if runtimeVersionErr != nil {
+		return nil, runtimeVersionErr
+	}
+	cExportDir := C.CString(exportDir)
+	defer C.free(unsafe.Pointer(cExportDir))
+
+	cTags := make([]*C.char, len(tags))
+	for i, t := range tags {
+		cTags[i] = C.CString(t)
+		defer C.free(unsafe.Pointer(cTags[i]))
+	}
+	var cTagsPtr **C.char
+	if len(cTags) > 0 {
+		cTagsPtr = &cTags[0]
+	}
+
+	opts := options.c()
+	defer C.TF_DeleteSessionOptions(opts)
+	graph := newGraph()
+	status := newStatus()
+	metaGraphBuf := C.TF_NewBuffer()
+	defer C.TF_DeleteBuffer(metaGraphBuf)
+
+	cSess := C.TF_LoadSessionFromSavedModel(opts, nil, cExportDir, cTagsPtr, C.int(len(cTags)), graph.c, metaGraphBuf, status.c)
+	if err := status.Err(); err != nil {
+		return nil, fmt.Errorf("tensorflow: LoadSavedModel(%q, %v): %v", exportDir, tags, err)
+	}
+
+	metaGraph, err := decodeMetaGraphDef(metaGraphBuf)
+	if err != nil {
+		return nil, fmt.Errorf("tensorflow: LoadSavedModel(%q, %v): decoding MetaGraphDef: %v", exportDir, tags, err)
+	}
+
+	sess := &Session{c: cSess}
+	runtime.SetFinalizer(sess, (*Session).finalize)
+
+	return &SavedModel{
+		Graph:      graph,
+		Session:    sess,
+		Signatures: metaGraph.GetSignatureDef(),
+	}, nil
+}
+
+// decodeMetaGraphDef unmarshals the MetaGraphDef proto held in buf.
+func decodeMetaGraphDef(buf *C.TF_Buffer) (*corepb.MetaGraphDef, error) {
+	data := C.GoBytes(buf.data, C.int(buf.length))
+	metaGraph := new(corepb.MetaGraphDef)
+	if err := metaGraph.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return metaGraph, nil
+}
+
+// Signature returns the named SignatureDef (e.g. "serving_default"),
+// or an error if the SavedModel does not declare one under that name.
+func (m *SavedModel) Signature(name string) (*corepb.SignatureDef, error) {
+	sig, ok := m.Signatures[name]
+	if !ok {
+		return nil, fmt.Errorf("tensorflow: no signature named %q in SavedModel", name)
+	}
+	return sig, nil
+}
+
+// Run feeds the tensors in feeds (keyed by input name in the named
+// SignatureDef) through the SavedModel's graph and returns the tensors
+// produced for every output declared in that SignatureDef, keyed by the
+// same output names.
+//
+// It is a convenience wrapper around Session.Run that resolves
+// signature input/output names to the Output handles the underlying ops
+// actually use.
+func (m *SavedModel) Run(signature string, feeds map[string]*Tensor) (map[string]*Tensor, error) {
+	sig, err := m.Signature(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make(map[Output]*Tensor, len(feeds))
+	for name, t := range feeds {
+		info, ok := sig.GetInputs()[name]
+		if !ok {
+			return nil, fmt.Errorf("tensorflow: signature %q has no input named %q", signature, name)
+		}
+		out, err := m.resolveOutput(info.GetName())
+		if err != nil {
+			return nil, err
+		}
+		inputs[out] = t
+	}
+
+	var fetchNames []string
+	var fetchOutputs []Output
+	for name, info := range sig.GetOutputs() {
+		out, err := m.resolveOutput(info.GetName())
+		if err != nil {
+			return nil, err
+		}
+		fetchNames = append(fetchNames, name)
+		fetchOutputs = append(fetchOutputs, out)
+	}
+
+	results, err := m.Session.Run(inputs, fetchOutputs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*Tensor, len(results))
+	for i, name := range fetchNames {
+		out[name] = results[i]
+	}
+	return out, nil
+}
+
+// resolveOutput turns a "op_name:index" tensor name, as stored in a
+// SignatureDef, into the Output handle the Graph exposes for it.
+func (m *SavedModel) resolveOutput(tensorName string) (Output, error) {
+	op, index, err := parseTensorName(tensorName)
+	if err != nil {
+		return Output{}, err
+	}
+	operation := m.Graph.Operation(op)
+	if operation == nil {
+		return Output{}, fmt.Errorf("tensorflow: SignatureDef refers to unknown operation %q", op)
+	}
+	return operation.Output(index), nil
+}
+
+// parseTensorName splits a "op_name:output_index" tensor name, the form
+// used in a SignatureDef's TensorInfo, into its op name and output index.
+// An omitted index defaults to 0, matching the TensorFlow convention.
+func parseTensorName(name string) (op string, index int, err error) {
+	parts := strings.SplitN(name, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], 0, nil
+	}
+	index, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, fmt.Errorf("tensorflow: invalid tensor name %q: %v", name, err)
+	}
+	return parts[0], index, nil
+}
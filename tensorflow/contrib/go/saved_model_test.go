@@ -0,0 +1,33 @@
+package tensorflow
+
+import "testing"
+
+func TestParseTensorName(t *testing.T) {
+	tests := []struct {
+		in        string
+		op        string
+		index     int
+		wantError bool
+	}{
+		{in: "Softmax:0", op: "Softmax", index: 0},
+		{in: "Placeholder_1:2", op: "Placeholder_1", index: 2},
+		{in: "Const", op: "Const", index: 0},
+		{in: "Softmax:oops", wantError: true},
+	}
+	for _, test := range tests {
+		op, index, err := parseTensorName(test.in)
+		if test.wantError {
+			if err == nil {
+				t.Errorf("parseTensorName(%q): got nil error, want one", test.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTensorName(%q): unexpected error: %v", test.in, err)
+			continue
+		}
+		if op != test.op || index != test.index {
+			t.Errorf("parseTensorName(%q) = (%q, %d), want (%q, %d)", test.in, op, index, test.op, test.index)
+		}
+	}
+}
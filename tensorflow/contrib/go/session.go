@@ -0,0 +1,119 @@
+package tensorflow
+
+// #include <stdlib.h>
+// #include "tensorflow/c/c_api.h"
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// SessionOptions contains configuration for a Session.
+type SessionOptions struct {
+	// Target is the TensorFlow runtime to connect to, as accepted by
+	// TF_SetTarget. An empty Target runs the graph in-process.
+	Target string
+}
+
+// c builds a TF_SessionOptions from o. A nil *SessionOptions is treated
+// as the zero value.
+func (o *SessionOptions) c() *C.TF_SessionOptions {
+	opts := C.TF_NewSessionOptions()
+	if o != nil && o.Target != "" {
+		cTarget := C.CString(o.Target)
+		defer C.free(unsafe.Pointer(cTarget))
+		C.TF_SetTarget(opts, cTarget)
+	}
+	return opts
+}
+
+// Session drives execution of a Graph's Operations.
+type Session struct {
+	c *C.TF_Session
+}
+
+// NewSession creates a new Session bound to graph.
+func NewSession(graph *Graph, options *SessionOptions) (*Session, error) {
+	if runtimeVersionErr != nil {
+		return nil, runtimeVersionErr
+	}
+	opts := options.c()
+	defer C.TF_DeleteSessionOptions(opts)
+	status := newStatus()
+	c := C.TF_NewSession(graph.c, opts, status.c)
+	if err := status.Err(); err != nil {
+		return nil, err
+	}
+	s := &Session{c: c}
+	runtime.SetFinalizer(s, (*Session).finalize)
+	return s, nil
+}
+
+func (s *Session) finalize() {
+	status := newStatus()
+	C.TF_DeleteSession(s.c, status.c)
+}
+
+// Run feeds every Tensor in feeds to its corresponding Output, executes
+// enough of the graph to compute every Output in fetches and every
+// Operation in targets, and returns one Tensor per entry in fetches.
+func (s *Session) Run(feeds map[Output]*Tensor, fetches []Output, targets []*Operation) ([]*Tensor, error) {
+	feedPorts := make([]C.TF_Output, 0, len(feeds))
+	feedTensors := make([]*C.TF_Tensor, 0, len(feeds))
+	for out, t := range feeds {
+		feedPorts = append(feedPorts, out.c())
+		feedTensors = append(feedTensors, t.c)
+	}
+
+	fetchPorts := make([]C.TF_Output, len(fetches))
+	for i, out := range fetches {
+		fetchPorts[i] = out.c()
+	}
+	fetchTensors := make([]*C.TF_Tensor, len(fetches))
+
+	targetOps := make([]*C.TF_Operation, len(targets))
+	for i, op := range targets {
+		targetOps[i] = op.c
+	}
+
+	var feedPortsPtr, fetchPortsPtr *C.TF_Output
+	var feedTensorsPtr, fetchTensorsPtr **C.TF_Tensor
+	var targetOpsPtr **C.TF_Operation
+	if len(feedPorts) > 0 {
+		feedPortsPtr, feedTensorsPtr = &feedPorts[0], &feedTensors[0]
+	}
+	if len(fetchPorts) > 0 {
+		fetchPortsPtr, fetchTensorsPtr = &fetchPorts[0], &fetchTensors[0]
+	}
+	if len(targetOps) > 0 {
+		targetOpsPtr = &targetOps[0]
+	}
+
+	status := newStatus()
+	C.TF_SessionRun(s.c, nil,
+		feedPortsPtr, feedTensorsPtr, C.int(len(feedPorts)),
+		fetchPortsPtr, fetchTensorsPtr, C.int(len(fetchPorts)),
+		targetOpsPtr, C.int(len(targetOps)),
+		nil, status.c)
+	if err := status.Err(); err != nil {
+		return nil, fmt.Errorf("tensorflow: Session.Run: %v", err)
+	}
+
+	results := make([]*Tensor, len(fetchTensors))
+	for i, c := range fetchTensors {
+		t := &Tensor{c: c}
+		runtime.SetFinalizer(t, (*Tensor).finalize)
+		results[i] = t
+	}
+	return results, nil
+}
+
+// Close releases the resources associated with the Session. The Session
+// must not be used afterwards.
+func (s *Session) Close() error {
+	status := newStatus()
+	C.TF_CloseSession(s.c, status.c)
+	return status.Err()
+}
@@ -0,0 +1,34 @@
+package tensorflow
+
+import "fmt"
+
+// Shape represents the shape of a Tensor or Output: a sequence of
+// dimension sizes. A dimension size of -1 means that dimension's size is
+// unknown.
+type Shape struct {
+	dims []int64
+}
+
+// ScalarShape returns the Shape of a scalar (rank 0) value.
+func ScalarShape() Shape {
+	return Shape{dims: []int64{}}
+}
+
+// MakeShape returns a Shape with the given dimension sizes.
+func MakeShape(dims ...int64) Shape {
+	return Shape{dims: append([]int64{}, dims...)}
+}
+
+// NumDimensions returns the rank of the shape.
+func (s Shape) NumDimensions() int {
+	return len(s.dims)
+}
+
+// Size returns the size of the i'th dimension, or -1 if it is unknown.
+func (s Shape) Size(i int) int64 {
+	return s.dims[i]
+}
+
+func (s Shape) String() string {
+	return fmt.Sprintf("%v", s.dims)
+}
@@ -0,0 +1,34 @@
+package tensorflow
+
+// #include "tensorflow/c/c_api.h"
+import "C"
+
+import (
+	"errors"
+	"runtime"
+)
+
+// status wraps a TF_Status, translating it into a Go error once an
+// operation that might fail has completed.
+type status struct {
+	c *C.TF_Status
+}
+
+func newStatus() *status {
+	s := &status{c: C.TF_NewStatus()}
+	runtime.SetFinalizer(s, (*status).finalize)
+	return s
+}
+
+func (s *status) finalize() {
+	C.TF_DeleteStatus(s.c)
+}
+
+// Err returns nil if the status is OK, and an error describing the
+// failure otherwise.
+func (s *status) Err() error {
+	if C.TF_GetCode(s.c) == C.TF_OK {
+		return nil
+	}
+	return errors.New(C.GoString(C.TF_Message(s.c)))
+}
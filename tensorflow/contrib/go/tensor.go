@@ -0,0 +1,204 @@
+package tensorflow
+
+// #include <string.h>
+// #include "tensorflow/c/c_api.h"
+import "C"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+// Tensor holds a multi-dimensional array of elements of a single data
+// type, backed by memory allocated and owned by the C API.
+//
+// It supports scalars and 1-dimensional slices of the numeric Go types
+// (bool, int8, int16, int32, int64, uint8, float32, float64, and int,
+// stored as Int64), plus scalar strings. Deeper nesting and other element types are rejected with an
+// error rather than silently misencoded.
+type Tensor struct {
+	c *C.TF_Tensor
+}
+
+// NewTensor converts value into a Tensor.
+func NewTensor(value interface{}) (*Tensor, error) {
+	val := reflect.ValueOf(value)
+	dataType, shape, err := inferTypeAndShape(val)
+	if err != nil {
+		return nil, fmt.Errorf("tensorflow: NewTensor: %v", err)
+	}
+	if dataType == String {
+		return newStringTensor(val.String())
+	}
+
+	dims := make([]C.int64_t, len(shape))
+	nflattened := int64(1)
+	for i, d := range shape {
+		dims[i] = C.int64_t(d)
+		nflattened *= d
+	}
+	elemSize, err := sizeof(dataType)
+	if err != nil {
+		return nil, err
+	}
+	nbytes := C.size_t(elemSize) * C.size_t(nflattened)
+
+	var dimsPtr *C.int64_t
+	if len(dims) > 0 {
+		dimsPtr = &dims[0]
+	}
+	c := C.TF_AllocateTensor(C.TF_DataType(dataType), dimsPtr, C.int(len(dims)), nbytes)
+	if c == nil {
+		return nil, fmt.Errorf("tensorflow: NewTensor: failed to allocate tensor of %d bytes", nbytes)
+	}
+	buf := (*[1 << 30]byte)(C.TF_TensorData(c))[:nbytes:nbytes]
+	if err := binary.Write(sliceWriter{buf}, nativeByteOrder, toEncodable(val).Interface()); err != nil {
+		C.TF_DeleteTensor(c)
+		return nil, fmt.Errorf("tensorflow: NewTensor: encoding value: %v", err)
+	}
+
+	t := &Tensor{c: c}
+	runtime.SetFinalizer(t, (*Tensor).finalize)
+	return t, nil
+}
+
+// newStringTensor encodes a scalar Go string into a TF_STRING tensor using
+// the classic offset-table encoding: a single 8-byte offset (always 0 for
+// a scalar), followed by the value varint-length-prefixed.
+func newStringTensor(s string) (*Tensor, error) {
+	var body bytes.Buffer
+	var lenBuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBuf[:], uint64(len(s)))
+	body.Write(lenBuf[:n])
+	body.WriteString(s)
+
+	nbytes := C.size_t(8 + body.Len())
+	c := C.TF_AllocateTensor(C.TF_STRING, nil, 0, nbytes)
+	if c == nil {
+		return nil, fmt.Errorf("tensorflow: NewTensor: failed to allocate string tensor of %d bytes", nbytes)
+	}
+	buf := (*[1 << 30]byte)(C.TF_TensorData(c))[:nbytes:nbytes]
+	nativeByteOrder.PutUint64(buf[:8], 0)
+	copy(buf[8:], body.Bytes())
+
+	t := &Tensor{c: c}
+	runtime.SetFinalizer(t, (*Tensor).finalize)
+	return t, nil
+}
+
+func (t *Tensor) finalize() {
+	C.TF_DeleteTensor(t.c)
+}
+
+// DataType returns the type of the Tensor's elements.
+func (t *Tensor) DataType() DataType {
+	return DataType(C.TF_TensorType(t.c))
+}
+
+// Shape returns the shape of the Tensor.
+func (t *Tensor) Shape() Shape {
+	numDims := int(C.TF_NumDims(t.c))
+	dims := make([]int64, numDims)
+	for i := range dims {
+		dims[i] = int64(C.TF_Dim(t.c, C.int(i)))
+	}
+	return Shape{dims: dims}
+}
+
+// inferTypeAndShape determines the DataType and shape of a scalar or
+// 1-dimensional slice value.
+func inferTypeAndShape(val reflect.Value) (DataType, []int64, error) {
+	switch val.Kind() {
+	case reflect.Bool:
+		return Bool, nil, nil
+	case reflect.Int8:
+		return Int8, nil, nil
+	case reflect.Int16:
+		return Int16, nil, nil
+	case reflect.Int32:
+		return Int32, nil, nil
+	case reflect.Int64, reflect.Int:
+		return Int64, nil, nil
+	case reflect.Uint8:
+		return Uint8, nil, nil
+	case reflect.Float32:
+		return Float, nil, nil
+	case reflect.Float64:
+		return Double, nil, nil
+	case reflect.String:
+		return String, nil, nil
+	case reflect.Slice:
+		if val.Len() == 0 {
+			return 0, nil, fmt.Errorf("cannot infer the data type of an empty slice")
+		}
+		elemType, _, err := inferTypeAndShape(val.Index(0))
+		if err != nil {
+			return 0, nil, err
+		}
+		if elemType == String {
+			return 0, nil, fmt.Errorf("slices of strings are not supported, only scalar strings")
+		}
+		return elemType, []int64{int64(val.Len())}, nil
+	default:
+		return 0, nil, fmt.Errorf("unsupported type %v", val.Type())
+	}
+}
+
+// toEncodable returns a value holding the same data as val but typed so
+// that encoding/binary.Write, which only understands fixed-size types,
+// can encode it. val is inferred as Int64 if it is a plain Go int (the
+// platform-sized type tf.NewTensor(42), the most natural literal call,
+// produces) or a slice of them; binary.Write rejects int/[]int outright,
+// so those are converted to int64/[]int64 first. Every other type
+// inferTypeAndShape accepts is already fixed-size and is returned as-is.
+func toEncodable(val reflect.Value) reflect.Value {
+	switch val.Kind() {
+	case reflect.Int:
+		return reflect.ValueOf(val.Int()).Convert(reflect.TypeOf(int64(0)))
+	case reflect.Slice:
+		if val.Type().Elem().Kind() != reflect.Int {
+			return val
+		}
+		out := reflect.MakeSlice(reflect.TypeOf([]int64(nil)), val.Len(), val.Len())
+		for i := 0; i < val.Len(); i++ {
+			out.Index(i).SetInt(val.Index(i).Int())
+		}
+		return out
+	default:
+		return val
+	}
+}
+
+// sizeof returns the number of bytes a single element of dataType
+// occupies.
+func sizeof(dataType DataType) (int, error) {
+	switch dataType {
+	case Bool, Int8, Uint8:
+		return 1, nil
+	case Int16:
+		return 2, nil
+	case Int32, Float:
+		return 4, nil
+	case Int64, Double:
+		return 8, nil
+	default:
+		return 0, fmt.Errorf("tensorflow: no fixed size for data type %v", dataType)
+	}
+}
+
+var nativeByteOrder = binary.LittleEndian
+
+// sliceWriter adapts a byte slice to an io.Writer so binary.Write can fill
+// the C-allocated tensor buffer directly.
+type sliceWriter struct {
+	buf []byte
+}
+
+func (w sliceWriter) Write(p []byte) (int, error) {
+	n := copy(w.buf, p)
+	w.buf = w.buf[n:]
+	return n, nil
+}
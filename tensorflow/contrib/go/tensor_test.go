@@ -0,0 +1,43 @@
+package tensorflow
+
+import "testing"
+
+func TestNewTensorInt(t *testing.T) {
+	// A plain Go int is the most natural literal passed to op.Const, but
+	// encoding/binary only understands fixed-size types, so NewTensor must
+	// convert it (and slices of it) to int64 before encoding.
+	tensor, err := NewTensor(42)
+	if err != nil {
+		t.Fatalf("NewTensor(42): %v", err)
+	}
+	if got, want := tensor.DataType(), Int64; got != want {
+		t.Errorf("NewTensor(42).DataType() = %v, want %v", got, want)
+	}
+	if got := tensor.Shape().NumDimensions(); got != 0 {
+		t.Errorf("NewTensor(42).Shape().NumDimensions() = %d, want 0 (scalar)", got)
+	}
+
+	sliceTensor, err := NewTensor([]int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("NewTensor([]int{1, 2, 3}): %v", err)
+	}
+	if got, want := sliceTensor.DataType(), Int64; got != want {
+		t.Errorf("NewTensor([]int{...}).DataType() = %v, want %v", got, want)
+	}
+	if got, want := sliceTensor.Shape().NumDimensions(), 1; got != want {
+		t.Errorf("NewTensor([]int{...}).Shape().NumDimensions() = %d, want %d", got, want)
+	}
+	if got, want := sliceTensor.Shape().Size(0), int64(3); got != want {
+		t.Errorf("NewTensor([]int{...}).Shape().Size(0) = %d, want %d", got, want)
+	}
+}
+
+func TestNewTensorInt64(t *testing.T) {
+	// Already-fixed-size types must keep working unchanged.
+	if _, err := NewTensor(int64(42)); err != nil {
+		t.Errorf("NewTensor(int64(42)): %v", err)
+	}
+	if _, err := NewTensor([]float32{1, 2, 3}); err != nil {
+		t.Errorf("NewTensor([]float32{...}): %v", err)
+	}
+}
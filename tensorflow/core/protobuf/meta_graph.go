@@ -0,0 +1,41 @@
+package protobuf
+
+// MetaGraphDef mirrors tensorflow/core/protobuf/meta_graph.proto,
+// decoding only the signature_def map (field 5), which is all
+// SavedModel loading in contrib/go needs from it.
+type MetaGraphDef struct {
+	SignatureDef map[string]*SignatureDef
+}
+
+// GetSignatureDef returns the MetaGraphDef's named signatures, or nil if
+// m is nil.
+func (m *MetaGraphDef) GetSignatureDef() map[string]*SignatureDef {
+	if m == nil {
+		return nil
+	}
+	return m.SignatureDef
+}
+
+// Unmarshal decodes the wire-format MetaGraphDef in data into m.
+func (m *MetaGraphDef) Unmarshal(data []byte) error {
+	return forEachField(data, func(num, wireType int, value []byte) error {
+		if num != 5 || wireType != wireBytes {
+			return nil // every other field is unused by SavedModel loading
+		}
+		key, val, err := decodeMapEntry(value, func(b []byte) (*SignatureDef, error) {
+			sig := new(SignatureDef)
+			if err := sig.unmarshal(b); err != nil {
+				return nil, err
+			}
+			return sig, nil
+		})
+		if err != nil {
+			return err
+		}
+		if m.SignatureDef == nil {
+			m.SignatureDef = make(map[string]*SignatureDef)
+		}
+		m.SignatureDef[key] = val
+		return nil
+	})
+}
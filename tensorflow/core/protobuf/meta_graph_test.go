@@ -0,0 +1,79 @@
+package protobuf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// appendTag appends a protobuf field tag (field number + wire type).
+func appendTag(buf *bytes.Buffer, num, wireType int) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], uint64(num)<<3|uint64(wireType))
+	buf.Write(b[:n])
+}
+
+// appendBytesField appends a length-delimited field.
+func appendBytesField(buf *bytes.Buffer, num int, value []byte) {
+	appendTag(buf, num, wireBytes)
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], uint64(len(value)))
+	buf.Write(b[:n])
+	buf.Write(value)
+}
+
+func encodeTensorInfo(name string) []byte {
+	var buf bytes.Buffer
+	appendBytesField(&buf, 1, []byte(name))
+	return buf.Bytes()
+}
+
+func encodeMapEntry(key string, value []byte) []byte {
+	var buf bytes.Buffer
+	appendBytesField(&buf, 1, []byte(key))
+	appendBytesField(&buf, 2, value)
+	return buf.Bytes()
+}
+
+func encodeSignatureDef(inputs, outputs map[string]string) []byte {
+	var buf bytes.Buffer
+	for k, v := range inputs {
+		appendBytesField(&buf, 1, encodeMapEntry(k, encodeTensorInfo(v)))
+	}
+	for k, v := range outputs {
+		appendBytesField(&buf, 2, encodeMapEntry(k, encodeTensorInfo(v)))
+	}
+	return buf.Bytes()
+}
+
+func encodeMetaGraphDef(signatures map[string][]byte) []byte {
+	var buf bytes.Buffer
+	for name, sig := range signatures {
+		appendBytesField(&buf, 5, encodeMapEntry(name, sig))
+	}
+	return buf.Bytes()
+}
+
+func TestMetaGraphDefUnmarshal(t *testing.T) {
+	sig := encodeSignatureDef(
+		map[string]string{"x": "Placeholder:0"},
+		map[string]string{"y": "Softmax:0"},
+	)
+	data := encodeMetaGraphDef(map[string][]byte{"serving_default": sig})
+
+	var meta MetaGraphDef
+	if err := meta.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+
+	got := meta.GetSignatureDef()["serving_default"]
+	if got == nil {
+		t.Fatal(`GetSignatureDef()["serving_default"] = nil`)
+	}
+	if name := got.GetInputs()["x"].GetName(); name != "Placeholder:0" {
+		t.Errorf(`inputs["x"].GetName() = %q, want "Placeholder:0"`, name)
+	}
+	if name := got.GetOutputs()["y"].GetName(); name != "Softmax:0" {
+		t.Errorf(`outputs["y"].GetName() = %q, want "Softmax:0"`, name)
+	}
+}
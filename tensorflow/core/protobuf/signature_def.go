@@ -0,0 +1,62 @@
+package protobuf
+
+// SignatureDef mirrors tensorflow/core/protobuf/signature_def.proto,
+// decoding the inputs map (field 1), outputs map (field 2) and
+// method_name (field 3).
+type SignatureDef struct {
+	Inputs     map[string]*TensorInfo
+	Outputs    map[string]*TensorInfo
+	MethodName string
+}
+
+// GetInputs returns the signature's named inputs, or nil if s is nil.
+func (s *SignatureDef) GetInputs() map[string]*TensorInfo {
+	if s == nil {
+		return nil
+	}
+	return s.Inputs
+}
+
+// GetOutputs returns the signature's named outputs, or nil if s is nil.
+func (s *SignatureDef) GetOutputs() map[string]*TensorInfo {
+	if s == nil {
+		return nil
+	}
+	return s.Outputs
+}
+
+func (s *SignatureDef) unmarshal(data []byte) error {
+	return forEachField(data, func(num, wireType int, value []byte) error {
+		switch num {
+		case 1:
+			key, val, err := decodeMapEntry(value, decodeTensorInfo)
+			if err != nil {
+				return err
+			}
+			if s.Inputs == nil {
+				s.Inputs = make(map[string]*TensorInfo)
+			}
+			s.Inputs[key] = val
+		case 2:
+			key, val, err := decodeMapEntry(value, decodeTensorInfo)
+			if err != nil {
+				return err
+			}
+			if s.Outputs == nil {
+				s.Outputs = make(map[string]*TensorInfo)
+			}
+			s.Outputs[key] = val
+		case 3:
+			s.MethodName = string(value)
+		}
+		return nil
+	})
+}
+
+func decodeTensorInfo(data []byte) (*TensorInfo, error) {
+	t := new(TensorInfo)
+	if err := t.unmarshal(data); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,27 @@
+package protobuf
+
+// TensorInfo mirrors tensorflow/core/protobuf/tensor_info.proto, decoding
+// only the "name" field (1) of the name/coo_sparse/composite_tensor
+// encoding oneof; SavedModel signatures produced by the standard Python
+// exporters always set it.
+type TensorInfo struct {
+	Name string
+}
+
+// GetName returns the tensor's "op_name:output_index" name, or "" if t is
+// nil.
+func (t *TensorInfo) GetName() string {
+	if t == nil {
+		return ""
+	}
+	return t.Name
+}
+
+func (t *TensorInfo) unmarshal(data []byte) error {
+	return forEachField(data, func(num, wireType int, value []byte) error {
+		if num == 1 && wireType == wireBytes {
+			t.Name = string(value)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,95 @@
+// Package protobuf provides hand-written decoders for the subset of
+// tensorflow/core/protobuf/meta_graph.proto, signature_def.proto and
+// tensor_info.proto that the Go SavedModel loader in
+// tensorflow/contrib/go/saved_model.go needs.
+//
+// It is not a general-purpose protobuf implementation: only the
+// wire-format fields actually read by that loader are decoded; everything
+// else is skipped. A real client should instead generate these types with
+// protoc once the full .proto sources are vendored into this tree.
+package protobuf
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// forEachField walks the top-level fields of a wire-format protobuf
+// message in data, invoking fn with the field number, wire type and raw
+// field bytes (the varint's minimal encoding, the 8 or 4 byte fixed
+// value, or the length-delimited payload, as appropriate).
+func forEachField(data []byte, fn func(num, wireType int, value []byte) error) error {
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return fmt.Errorf("protobuf: invalid field tag")
+		}
+		data = data[n:]
+		num, wireType := int(tag>>3), int(tag&0x7)
+
+		var value []byte
+		switch wireType {
+		case wireVarint:
+			_, n := binary.Uvarint(data)
+			if n <= 0 {
+				return fmt.Errorf("protobuf: invalid varint value")
+			}
+			value, data = data[:n], data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return fmt.Errorf("protobuf: truncated fixed64 value")
+			}
+			value, data = data[:8], data[8:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 {
+				return fmt.Errorf("protobuf: invalid length-delimited size")
+			}
+			data = data[n:]
+			if uint64(len(data)) < length {
+				return fmt.Errorf("protobuf: truncated length-delimited value")
+			}
+			value, data = data[:length], data[length:]
+		case wireFixed32:
+			if len(data) < 4 {
+				return fmt.Errorf("protobuf: truncated fixed32 value")
+			}
+			value, data = data[:4], data[4:]
+		default:
+			return fmt.Errorf("protobuf: unsupported wire type %d", wireType)
+		}
+		if err := fn(num, wireType, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeMapEntry decodes a protobuf map<string, V> entry: the wire format
+// represents each entry as its own two-field message, field 1 being the
+// string key and field 2 the value, decoded here by decodeValue.
+func decodeMapEntry[V any](data []byte, decodeValue func([]byte) (V, error)) (string, V, error) {
+	var key string
+	var val V
+	err := forEachField(data, func(num, wireType int, value []byte) error {
+		switch num {
+		case 1:
+			key = string(value)
+		case 2:
+			v, err := decodeValue(value)
+			if err != nil {
+				return err
+			}
+			val = v
+		}
+		return nil
+	})
+	return key, val, err
+}